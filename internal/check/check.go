@@ -0,0 +1,7 @@
+// Package check defines the core types templates and checks are built from: the parameter
+// metadata a template declares, and the function a template instantiates to evaluate an object.
+package check
+
+// Func is the function a template instantiates from its validated parameters; it evaluates a
+// single object and reports whether it violates the rule the template encodes.
+type Func func(object interface{}) (bool, string)
@@ -0,0 +1,66 @@
+package check
+
+// ParameterType identifies the JSON/Go type a template parameter decodes to.
+type ParameterType string
+
+// The supported parameter types. These map directly onto JSON Schema's "type" keyword.
+const (
+	StringType  ParameterType = "string"
+	IntegerType ParameterType = "integer"
+	NumberType  ParameterType = "number"
+	BooleanType ParameterType = "boolean"
+	ArrayType   ParameterType = "array"
+	ObjectType  ParameterType = "object"
+)
+
+// ParameterDesc describes a single parameter of a template, as derived from its Params
+// struct by the codegen in internal/templates/codegen. It is both the source of the
+// human/machine-readable metadata (docs, JSON Schema) and of the validation rules the
+// generated Validate() enforces.
+type ParameterDesc struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description,omitempty"`
+	Type        ParameterType `json:"type"`
+
+	Required bool     `json:"required,omitempty"`
+	Enum     []string `json:"enum,omitempty"`
+	Examples []string `json:"examples,omitempty"`
+
+	// NoRegex and NotNegatable constrain how a string parameter's value may be used by the
+	// check that consumes it (e.g. whether it may be interpreted as a regular expression).
+	NoRegex      bool `json:"noRegex,omitempty"`
+	NotNegatable bool `json:"notNegatable,omitempty"`
+
+	// Pattern is a regular expression the parameter's (string) value must match.
+	Pattern string `json:"pattern,omitempty"`
+	// Min and Max bound a numeric parameter's value.
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+	// MinLength and MaxLength bound a string or array parameter's length.
+	MinLength *int `json:"minLength,omitempty"`
+	MaxLength *int `json:"maxLength,omitempty"`
+	// UniqueItems requires an array parameter's elements to be pairwise distinct.
+	UniqueItems bool `json:"uniqueItems,omitempty"`
+	// Format is a free-form hint about a parameter's expected shape (e.g. "duration"),
+	// analogous to JSON Schema's "format" keyword.
+	Format string `json:"format,omitempty"`
+	// Default is the literal Go expression (for non-string types) or raw string value (for
+	// string types) used to populate the field when no value is otherwise supplied.
+	Default string `json:"default,omitempty"`
+
+	// SubParameters describes the fields of an object-typed parameter.
+	SubParameters []ParameterDesc `json:"subParameters,omitempty"`
+	// ArrayElemType is the element type of an array-typed parameter.
+	ArrayElemType ParameterType `json:"arrayElemType,omitempty"`
+	// AdditionalProperties describes the value type of a map-typed object parameter.
+	AdditionalProperties *ParameterDesc `json:"additionalProperties,omitempty"`
+
+	// XXXStructFieldName is the (dotted, for fields of a +group member or struct-typed field) path to the
+	// corresponding field on the generated Params struct. It is exported only so that the
+	// codegen template can reference it; callers outside codegen should not depend on it.
+	XXXStructFieldName string `json:"-"`
+	// XXXIsPointer records whether the underlying Params field is a pointer to the type
+	// implied by Type. The generated Validate() and NewParams() do not consult it: they use
+	// the field as a value of that type.
+	XXXIsPointer bool `json:"-"`
+}
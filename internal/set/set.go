@@ -0,0 +1,46 @@
+// Package set provides small string set helpers used across the codebase in place of
+// map[string]struct{} boilerplate.
+package set
+
+// StringSet is a mutable set of strings.
+type StringSet map[string]struct{}
+
+// NewStringSet creates a StringSet containing the given initial elements.
+func NewStringSet(initial ...string) StringSet {
+	s := make(StringSet, len(initial))
+	for _, elem := range initial {
+		s[elem] = struct{}{}
+	}
+	return s
+}
+
+// Add inserts elem into the set, returning true if it was not already present.
+func (s StringSet) Add(elem string) bool {
+	if _, ok := s[elem]; ok {
+		return false
+	}
+	s[elem] = struct{}{}
+	return true
+}
+
+// Contains reports whether elem is in the set.
+func (s StringSet) Contains(elem string) bool {
+	_, ok := s[elem]
+	return ok
+}
+
+// FrozenStringSet is an immutable set of strings, for values fixed at construction time
+// (e.g. package-level allow/deny lists).
+type FrozenStringSet struct {
+	underlying StringSet
+}
+
+// NewFrozenStringSet creates a FrozenStringSet containing the given elements.
+func NewFrozenStringSet(elems ...string) FrozenStringSet {
+	return FrozenStringSet{underlying: NewStringSet(elems...)}
+}
+
+// Contains reports whether elem is in the set.
+func (s FrozenStringSet) Contains(elem string) bool {
+	return s.underlying.Contains(elem)
+}
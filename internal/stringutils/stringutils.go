@@ -0,0 +1,14 @@
+// Package stringutils provides small string helpers shared across the codebase.
+package stringutils
+
+import "strings"
+
+// Split2 splits s on the first occurrence of sep, returning the two halves. If sep does not
+// occur in s, the second return value is empty.
+func Split2(s, sep string) (string, string) {
+	parts := strings.SplitN(s, sep, 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"testing"
+
+	"golang.stackrox.io/kube-linter/internal/check"
+)
+
+func TestParamDescToJSONSchemaScalar(t *testing.T) {
+	pattern := `^v[0-9]+\.[0-9]+$`
+	desc := check.ParameterDesc{
+		Name:        "version",
+		Type:        check.StringType,
+		Description: "the required version",
+		Required:    true,
+		Pattern:     pattern,
+		Examples:    []string{"v1.0"},
+	}
+
+	schema, err := paramDescToJSONSchema(desc)
+	if err != nil {
+		t.Fatalf("paramDescToJSONSchema: %v", err)
+	}
+
+	if schema["type"] != "string" {
+		t.Errorf("type = %v, want %q", schema["type"], "string")
+	}
+	if schema["pattern"] != pattern {
+		t.Errorf("pattern = %v, want %q", schema["pattern"], pattern)
+	}
+	if schema["description"] != desc.Description {
+		t.Errorf("description = %v, want %q", schema["description"], desc.Description)
+	}
+}
+
+func TestParamDescToJSONSchemaObjectRecursesIntoSubParameters(t *testing.T) {
+	desc := check.ParameterDesc{
+		Name: "selector",
+		Type: check.ObjectType,
+		SubParameters: []check.ParameterDesc{
+			{Name: "kind", Type: check.StringType, Required: true},
+			{Name: "replicas", Type: check.IntegerType},
+		},
+	}
+
+	schema, err := paramDescToJSONSchema(desc)
+	if err != nil {
+		t.Fatalf("paramDescToJSONSchema: %v", err)
+	}
+
+	properties, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected properties map, got %v", schema["properties"])
+	}
+	if _, ok := properties["kind"]; !ok {
+		t.Errorf("expected properties to contain %q, got %v", "kind", properties)
+	}
+	if _, ok := properties["replicas"]; !ok {
+		t.Errorf("expected properties to contain %q, got %v", "replicas", properties)
+	}
+
+	required, ok := schema["required"].([]string)
+	if !ok || len(required) != 1 || required[0] != "kind" {
+		t.Errorf("required = %v, want [\"kind\"]", schema["required"])
+	}
+}
+
+func TestParamDescToJSONSchemaMapUsesAdditionalProperties(t *testing.T) {
+	desc := check.ParameterDesc{
+		Name:                 "labels",
+		Type:                 check.ObjectType,
+		AdditionalProperties: &check.ParameterDesc{Type: check.StringType},
+	}
+
+	schema, err := paramDescToJSONSchema(desc)
+	if err != nil {
+		t.Fatalf("paramDescToJSONSchema: %v", err)
+	}
+
+	additional, ok := schema["additionalProperties"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected additionalProperties schema, got %v", schema["additionalProperties"])
+	}
+	if additional["type"] != "string" {
+		t.Errorf("additionalProperties.type = %v, want %q", additional["type"], "string")
+	}
+	if _, ok := schema["properties"]; ok {
+		t.Errorf("map-typed object should not have a properties key, got %v", schema["properties"])
+	}
+}
+
+func TestParamDescToJSONSchemaArrayUsesElemType(t *testing.T) {
+	desc := check.ParameterDesc{
+		Name:          "modes",
+		Type:          check.ArrayType,
+		ArrayElemType: check.StringType,
+	}
+
+	schema, err := paramDescToJSONSchema(desc)
+	if err != nil {
+		t.Fatalf("paramDescToJSONSchema: %v", err)
+	}
+
+	items, ok := schema["items"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected items schema, got %v", schema["items"])
+	}
+	if items["type"] != "string" {
+		t.Errorf("items.type = %v, want %q", items["type"], "string")
+	}
+}
+
+func TestTemplateParamsToJSONSchemaMarksRequiredTopLevelFields(t *testing.T) {
+	schema, err := templateParamsToJSONSchema([]check.ParameterDesc{
+		{Name: "name", Type: check.StringType, Required: true},
+		{Name: "count", Type: check.IntegerType},
+	})
+	if err != nil {
+		t.Fatalf("templateParamsToJSONSchema: %v", err)
+	}
+
+	if schema["$schema"] != jsonSchemaDraft {
+		t.Errorf("$schema = %v, want %q", schema["$schema"], jsonSchemaDraft)
+	}
+	required, ok := schema["required"].([]string)
+	if !ok || len(required) != 1 || required[0] != "name" {
+		t.Errorf("required = %v, want [\"name\"]", schema["required"])
+	}
+}
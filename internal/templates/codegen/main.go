@@ -3,32 +3,41 @@ package main
 import (
 	"bytes"
 	"encoding/json"
+	"flag"
 	"fmt"
+	"go/ast"
+	"go/types"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 	"text/template"
 
 	"github.com/Masterminds/sprig/v3"
 	"github.com/pkg/errors"
+	"golang.org/x/tools/go/packages"
 	"golang.stackrox.io/kube-linter/internal/check"
 	"golang.stackrox.io/kube-linter/internal/set"
 	"golang.stackrox.io/kube-linter/internal/stringutils"
 	"golang.stackrox.io/kube-linter/internal/utils"
-	"k8s.io/gengo/parser"
-	"k8s.io/gengo/types"
 )
 
 var (
-	knownNonTemplateDirs = set.NewFrozenStringSet("all", "codegen", "util")
+	knownNonTemplateDirs = set.NewFrozenStringSet("all", "codegen", "util", "testutil")
+
+	schemaOut = flag.String("schema-out", ".", "directory to write the generated JSON schema files to")
+	docsOut   = flag.String("docs-out", "docs/generated", "directory to write the generated Markdown parameter reference to")
 )
 
 const (
 	metadataMarker = "+"
 
 	paramsStructName = "Params"
+
+	packagesLoadMode = packages.NeedTypes | packages.NeedTypesInfo | packages.NeedSyntax
 )
 
 type templateElem struct {
@@ -36,27 +45,43 @@ type templateElem struct {
 	ParamJSON string
 }
 
+// fileTemplateData is the top-level input to fileTemplate. Params holds every top-level
+// parameter and drives ParamDescs, NewParams defaults, and the With* options. ValidateParams
+// holds the same parameters with any struct-backed object flattened down to its leaves (see
+// flattenParamDescsForValidation), so Validate() can check each underlying field directly
+// instead of tripping over the object wrapper.
+type fileTemplateData struct {
+	Params         []templateElem
+	ValidateParams []templateElem
+}
+
 const (
 	fileTemplateStr = `// Code generated by kube-linter template codegen. DO NOT EDIT.
-// +build !templatecodegen
 
 package params
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
+	"testing"
 
 	"github.com/pkg/errors"
 	"golang.stackrox.io/kube-linter/internal/check"
 	"golang.stackrox.io/kube-linter/internal/templates/util"
 )
 
+var (
+	// Use regexp in case no pattern-validated parameter needs it.
+	_ = regexp.MustCompile
+)
+
 var (
 	// Use some imports in case they don't get used otherwise.
 	_ = util.MustParseParameterDesc
 	_ = fmt.Sprintf
 
-{{- range . }}
+{{- range .Params }}
 
 	{{ .ParamDesc.Name}}ParamDesc = util.MustParseParameterDesc({{backtick}}
 {{- .ParamJSON -}}
@@ -64,7 +89,7 @@ var (
 {{- end }}
 
 	ParamDescs = []check.ParameterDesc{
-		{{- range . }}
+		{{- range .Params }}
 		{{ .ParamDesc.Name}}ParamDesc,
 		{{- end }}
 	}
@@ -72,35 +97,108 @@ var (
 
 func (p *Params) Validate() error {
 	var validationErrors []string
-	{{- range . }}
-	{{- if eq .ParamDesc.Type "object" }} 
+	{{- range .ValidateParams }}
+	{{- if eq .ParamDesc.Type "object" }}
 	return errors.Errorf("parameter validation not yet supported for object type \"{{ .ParamDesc.Key }}\"")
 	{{- end }}
 	{{- if .ParamDesc.Required }}
-	{{- if ne .ParamDesc.Type "string" }}
-	return errors.Errorf("required parameter validation is currently only supported for strings, but {{ .ParamDesc.Key }} is not")
-	{{- end }}
+	{{- if eq .ParamDesc.Type "string" }}
 	if p.{{ .ParamDesc.XXXStructFieldName }} == "" {
 		validationErrors = append(validationErrors, "required param {{.ParamDesc.Name}} not found")
 	}
+	{{- else if eq .ParamDesc.Type "array" }}
+	if len(p.{{ .ParamDesc.XXXStructFieldName }}) == 0 {
+		validationErrors = append(validationErrors, "required param {{.ParamDesc.Name}} not found")
+	}
+	{{- else if or (eq .ParamDesc.Type "integer") (eq .ParamDesc.Type "number") }}
+	if p.{{ .ParamDesc.XXXStructFieldName }} == 0 {
+		validationErrors = append(validationErrors, "required param {{.ParamDesc.Name}} not found")
+	}
+	{{- else if eq .ParamDesc.Type "boolean" }}
+	if !p.{{ .ParamDesc.XXXStructFieldName }} {
+		validationErrors = append(validationErrors, "required param {{.ParamDesc.Name}} not found")
+	}
+	{{- else }}
+	return errors.Errorf("required parameter validation is not supported for type \"{{ .ParamDesc.Type }}\" ({{ .ParamDesc.Name }})")
+	{{- end }}
+	{{- end }}
+	{{- if .ParamDesc.Pattern }}
+	if matched, err := regexp.MatchString({{ printf "%q" .ParamDesc.Pattern }}, p.{{ .ParamDesc.XXXStructFieldName }}); err != nil {
+		validationErrors = append(validationErrors, fmt.Sprintf("param {{ .ParamDesc.Name }} could not be validated against its pattern: %v", err))
+	} else if !matched {
+		validationErrors = append(validationErrors, "param {{ .ParamDesc.Name }} does not match required pattern {{ .ParamDesc.Pattern }}")
+	}
+	{{- end }}
+	{{- if .ParamDesc.MinLength }}
+	if length := len(p.{{ .ParamDesc.XXXStructFieldName }}); length < {{ intVal .ParamDesc.MinLength }} {
+		validationErrors = append(validationErrors, fmt.Sprintf("param {{ .ParamDesc.Name }} must have length at least {{ intVal .ParamDesc.MinLength }}, got %d", length))
+	}
+	{{- end }}
+	{{- if .ParamDesc.MaxLength }}
+	if length := len(p.{{ .ParamDesc.XXXStructFieldName }}); length > {{ intVal .ParamDesc.MaxLength }} {
+		validationErrors = append(validationErrors, fmt.Sprintf("param {{ .ParamDesc.Name }} must have length at most {{ intVal .ParamDesc.MaxLength }}, got %d", length))
+	}
+	{{- end }}
+	{{- if .ParamDesc.Min }}
+	if float64(p.{{ .ParamDesc.XXXStructFieldName }}) < {{ floatVal .ParamDesc.Min }} {
+		validationErrors = append(validationErrors, fmt.Sprintf("param {{ .ParamDesc.Name }} must be at least {{ floatVal .ParamDesc.Min }}, got %v", p.{{ .ParamDesc.XXXStructFieldName }}))
+	}
+	{{- end }}
+	{{- if .ParamDesc.Max }}
+	if float64(p.{{ .ParamDesc.XXXStructFieldName }}) > {{ floatVal .ParamDesc.Max }} {
+		validationErrors = append(validationErrors, fmt.Sprintf("param {{ .ParamDesc.Name }} must be at most {{ floatVal .ParamDesc.Max }}, got %v", p.{{ .ParamDesc.XXXStructFieldName }}))
+	}
+	{{- end }}
+	{{- if .ParamDesc.UniqueItems }}
+	{
+		seen := make(map[interface{}]bool, len(p.{{ .ParamDesc.XXXStructFieldName }}))
+		for _, elem := range p.{{ .ParamDesc.XXXStructFieldName }} {
+			if seen[elem] {
+				validationErrors = append(validationErrors, "param {{ .ParamDesc.Name }} must not contain duplicate elements")
+				break
+			}
+			seen[elem] = true
+		}
+	}
 	{{- end }}
 	{{- if .ParamDesc.Enum }}
-	var found bool
-	for _, allowedValue := range []string{
+	{{- $elem := . }}
+	{{- $goType := paramGoType .ParamDesc }}
+	{{- if eq .ParamDesc.Type "array" }}
+	for _, elemValue := range p.{{ .ParamDesc.XXXStructFieldName }} {
+		var foundElem{{ optionName .ParamDesc.XXXStructFieldName }} bool
+		for _, allowedValue := range []{{ $goType }}{
+			{{- range .ParamDesc.Enum }}
+			{{ formatEnumValue $elem.ParamDesc . }},
+			{{- end }}
+		}{
+			if elemValue == allowedValue {
+				foundElem{{ optionName .ParamDesc.XXXStructFieldName }} = true
+				break
+			}
+		}
+		if !foundElem{{ optionName .ParamDesc.XXXStructFieldName }} {
+			validationErrors = append(validationErrors, fmt.Sprintf("param {{ .ParamDesc.Name }} has invalid value %v, must be one of {{ .ParamDesc.Enum }}", elemValue))
+		}
+	}
+	{{- else }}
+	var found{{ optionName .ParamDesc.XXXStructFieldName }} bool
+	for _, allowedValue := range []{{ $goType }}{
 		{{- range .ParamDesc.Enum }}
-		"{{ . }}",
+		{{ formatEnumValue $elem.ParamDesc . }},
 		{{- end }}
 	}{
 		if p.{{ .ParamDesc.XXXStructFieldName }} == allowedValue {
-			found = true
+			found{{ optionName .ParamDesc.XXXStructFieldName }} = true
 			break
 		}
 	}
-	if !found {
-		validationErrors = append(validationErrors, fmt.Sprintf("param {{ .ParamDesc.Name }} has invalid value %q, must be one of {{ .ParamDesc.Enum }}", p.{{ .ParamDesc.XXXStructFieldName }}))
+	if !found{{ optionName .ParamDesc.XXXStructFieldName }} {
+		validationErrors = append(validationErrors, fmt.Sprintf("param {{ .ParamDesc.Name }} has invalid value %v, must be one of {{ .ParamDesc.Enum }}", p.{{ .ParamDesc.XXXStructFieldName }}))
 	}
 	{{- end }}
 	{{- end }}
+	{{- end }}
 	if len(validationErrors) > 0 {
 		return errors.Errorf("invalid parameters: %s", strings.Join(validationErrors, ", "))
     }
@@ -128,6 +226,55 @@ func WrapInstantiateFunc(f func(p Params) (check.Func, error)) func (interface{}
 		return f(paramsInt.(Params))
 	}
 }
+
+// Option mutates a Params while it's being built via NewParams, analogous to the functional
+// options pattern used elsewhere in this repo.
+type Option func(*Params)
+
+// NewParams builds a Params, applying {{backtick}}+default{{backtick}}-tagged field defaults before any
+// passed-in Option, so tests can construct only the fields they care about. A string-typed
+// default is the tag's literal value; every other type's default (including array, which is a
+// Go slice literal, e.g. {{backtick}}+default=[]string{"a", "b"}{{backtick}}) is spliced in verbatim.
+func NewParams(opts ...Option) Params {
+	var p Params
+	{{- range .Params }}
+	{{- if .ParamDesc.Default }}
+	{{- if eq .ParamDesc.Type "string" }}
+	p.{{ .ParamDesc.XXXStructFieldName }} = {{ printf "%q" .ParamDesc.Default }}
+	{{- else }}
+	p.{{ .ParamDesc.XXXStructFieldName }} = {{ .ParamDesc.Default }}
+	{{- end }}
+	{{- end }}
+	{{- end }}
+	for _, opt := range opts {
+		opt(&p)
+	}
+	return p
+}
+{{ range .Params }}
+{{- if ne .ParamDesc.Type "object" }}
+// With{{ optionName .ParamDesc.XXXStructFieldName }} sets the {{ .ParamDesc.Name }} parameter.
+func With{{ optionName .ParamDesc.XXXStructFieldName }}(v {{ fieldGoType .ParamDesc }}) Option {
+	return func(p *Params) {
+		p.{{ .ParamDesc.XXXStructFieldName }} = v
+	}
+}
+{{ end }}
+{{- end }}
+// MustInstantiate validates p and instantiates it via instantiate, failing t immediately
+// (via t.Fatal) if either step returns an error. It exists so table-driven template tests
+// don't each need to hand-roll that two-step dance.
+func MustInstantiate(t testing.TB, p Params, instantiate func(Params) (check.Func, error)) check.Func {
+	t.Helper()
+	if err := p.Validate(); err != nil {
+		t.Fatalf("invalid params: %v", err)
+	}
+	checkFunc, err := instantiate(p)
+	if err != nil {
+		t.Fatalf("instantiating check func: %v", err)
+	}
+	return checkFunc
+}
 `
 )
 
@@ -136,32 +283,133 @@ var (
 		"backtick": func() string {
 			return "`"
 		},
+		"intVal": func(i *int) int {
+			return *i
+		},
+		"floatVal": func(f *float64) float64 {
+			return *f
+		},
+		"paramGoType": func(desc check.ParameterDesc) (string, error) {
+			typ := desc.Type
+			if typ == check.ArrayType {
+				// Enum on an array param constrains each element, so the allowed-value
+				// literal needs the element's Go type, not []T.
+				typ = desc.ArrayElemType
+			}
+			switch typ {
+			case check.StringType:
+				return "string", nil
+			case check.IntegerType:
+				return "int", nil
+			case check.NumberType:
+				return "float64", nil
+			default:
+				return "", errors.Errorf("enum validation is not supported for type %v", typ)
+			}
+		},
+		"formatEnumValue": func(desc check.ParameterDesc, val string) (string, error) {
+			typ := desc.Type
+			if typ == check.ArrayType {
+				typ = desc.ArrayElemType
+			}
+			switch typ {
+			case check.StringType:
+				return fmt.Sprintf("%q", val), nil
+			case check.IntegerType, check.NumberType:
+				return val, nil
+			default:
+				return "", errors.Errorf("enum validation is not supported for type %v", typ)
+			}
+		},
+		"optionName": func(xxxStructFieldName string) string {
+			return strings.ReplaceAll(xxxStructFieldName, ".", "")
+		},
+		"fieldGoType": func(desc check.ParameterDesc) (string, error) {
+			switch desc.Type {
+			case check.BooleanType:
+				return "bool", nil
+			case check.ArrayType:
+				elemGoType, err := scalarGoType(desc.ArrayElemType)
+				if err != nil {
+					return "", errors.Wrapf(err, "field %s", desc.Name)
+				}
+				return "[]" + elemGoType, nil
+			default:
+				return scalarGoType(desc.Type)
+			}
+		},
 	}).Parse(fileTemplateStr))
 )
 
+// scalarGoType maps a non-array, non-object check.ParameterType to its Go type, for use by
+// generated builder options.
+func scalarGoType(typ check.ParameterType) (string, error) {
+	switch typ {
+	case check.StringType:
+		return "string", nil
+	case check.IntegerType:
+		return "int", nil
+	case check.NumberType:
+		return "float64", nil
+	case check.BooleanType:
+		return "bool", nil
+	default:
+		return "", errors.Errorf("no Go type known for parameter type %v", typ)
+	}
+}
+
 func lowerCaseFirstLetter(s string) string {
 	return strings.ToLower(s[:1]) + s[1:]
 }
 
-func getName(member types.Member) string {
-	if jsonTag := reflect.StructTag(member.Tags).Get("json"); jsonTag != "" {
+func getName(fieldName string, tag reflect.StructTag) string {
+	if jsonTag := tag.Get("json"); jsonTag != "" {
 		name, _ := stringutils.Split2(jsonTag, ",")
 		if name != "" {
 			return name
 		}
 	}
-	return lowerCaseFirstLetter(member.Name)
+	return lowerCaseFirstLetter(fieldName)
 }
 
-func getDescription(member types.Member) string {
-	firstCommentLineWithMetadata := len(member.CommentLines)
-	for i, commentLine := range member.CommentLines {
+// getDescription returns everything in commentLines before the first `+`-marker line,
+// joined into a single sentence, the same way the old gengo-based comment handling did.
+func getDescription(commentLines []string) string {
+	firstCommentLineWithMetadata := len(commentLines)
+	for i, commentLine := range commentLines {
 		if strings.HasPrefix(commentLine, metadataMarker) {
 			firstCommentLineWithMetadata = i
 			break
 		}
 	}
-	return strings.Join(member.CommentLines[:firstCommentLineWithMetadata], " ")
+	return strings.Join(commentLines[:firstCommentLineWithMetadata], " ")
+}
+
+// extractCommentTags is a minimal stand-in for gengo's types.ExtractCommentTags, pulling
+// out `+key=value` (or bare `+key`) lines from a Godoc comment.
+func extractCommentTags(marker string, commentLines []string) map[string][]string {
+	tags := make(map[string][]string)
+	for _, line := range commentLines {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, marker) {
+			continue
+		}
+		key, val := stringutils.Split2(strings.TrimPrefix(line, marker), "=")
+		tags[key] = append(tags[key], val)
+	}
+	return tags
+}
+
+// commentGroupLines splits an AST comment group's text into individual, marker-friendly lines.
+func commentGroupLines(doc *ast.CommentGroup) []string {
+	if doc == nil {
+		return nil
+	}
+	text := strings.TrimRight(doc.Text(), "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
 }
 
 func setBoolBasedOnPresenceOfTag(valToSet *bool, tag string, extractedTags map[string][]string) error {
@@ -174,50 +422,311 @@ func setBoolBasedOnPresenceOfTag(valToSet *bool, tag string, extractedTags map[s
 	return nil
 }
 
-func constructParameterDescsFromStruct(typeSpec *types.Type) ([]check.ParameterDesc, error) {
+func setStringBasedOnValueOfTag(valToSet *string, tag string, extractedTags map[string][]string) error {
+	val, exists := extractedTags[tag]
+	if !exists {
+		return nil
+	}
+	if len(val) != 1 || val[0] == "" {
+		return errors.Errorf("invalid value for tag %s: %v; tag requires exactly one value", tag, val)
+	}
+	*valToSet = val[0]
+	return nil
+}
+
+func setIntPtrBasedOnValueOfTag(valToSet **int, tag string, extractedTags map[string][]string) error {
+	val, exists := extractedTags[tag]
+	if !exists {
+		return nil
+	}
+	if len(val) != 1 || val[0] == "" {
+		return errors.Errorf("invalid value for tag %s: %v; tag requires exactly one integer value", tag, val)
+	}
+	parsed, err := strconv.Atoi(val[0])
+	if err != nil {
+		return errors.Wrapf(err, "parsing value for tag %s", tag)
+	}
+	*valToSet = &parsed
+	return nil
+}
+
+func setFloatPtrBasedOnValueOfTag(valToSet **float64, tag string, extractedTags map[string][]string) error {
+	val, exists := extractedTags[tag]
+	if !exists {
+		return nil
+	}
+	if len(val) != 1 || val[0] == "" {
+		return errors.Errorf("invalid value for tag %s: %v; tag requires exactly one numeric value", tag, val)
+	}
+	parsed, err := strconv.ParseFloat(val[0], 64)
+	if err != nil {
+		return errors.Wrapf(err, "parsing value for tag %s", tag)
+	}
+	*valToSet = &parsed
+	return nil
+}
+
+// getCheckTypeFromGoType maps a go/types type to a check.ParameterType, looking through
+// the type's underlying basic kind. It also infers the `format` "duration" for time.Duration
+// (the only named type it recognises), the way a JSON Schema "format" keyword would be populated.
+func getCheckTypeFromGoType(t types.Type) (check.ParameterType, string, error) {
+	basic, ok := t.Underlying().(*types.Basic)
+	if !ok {
+		return "", "", errors.Errorf("currently unsupported type %v", t)
+	}
+	var paramType check.ParameterType
+	switch basic.Kind() {
+	case types.String:
+		paramType = check.StringType
+	case types.Int, types.Int8, types.Int16, types.Int32, types.Int64,
+		types.Uint, types.Uint8, types.Uint16, types.Uint32, types.Uint64:
+		paramType = check.IntegerType
+	case types.Float32, types.Float64:
+		paramType = check.NumberType
+	case types.Bool:
+		paramType = check.BooleanType
+	default:
+		return "", "", errors.Errorf("currently unsupported basic type %v", basic)
+	}
+
+	var format string
+	if named, ok := t.(*types.Named); ok {
+		obj := named.Obj()
+		if pkg := obj.Pkg(); pkg != nil && pkg.Path() == "time" && obj.Name() == "Duration" {
+			format = "duration"
+		}
+	}
+	return paramType, format, nil
+}
+
+type astField struct {
+	name string
+	doc  *ast.CommentGroup
+}
+
+// exprName best-efforts a name out of an (possibly embedded) field's type expression, for
+// fields that don't have an explicit identifier of their own.
+func exprName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.StarExpr:
+		return exprName(t.X)
+	default:
+		return ""
+	}
+}
+
+// flattenASTFields walks a struct's AST field list, expanding grouped declarations
+// (e.g. `A, B string`) into one entry per name, in declaration order, so that it lines
+// up positionally with types.Struct's NumFields/Field.
+func flattenASTFields(st *ast.StructType) []astField {
+	var out []astField
+	for _, f := range st.Fields.List {
+		if len(f.Names) == 0 {
+			out = append(out, astField{name: exprName(f.Type), doc: f.Doc})
+			continue
+		}
+		for _, name := range f.Names {
+			out = append(out, astField{name: name.Name, doc: f.Doc})
+		}
+	}
+	return out
+}
+
+// findStructASTByName looks for the Go source of a named struct type declared anywhere in
+// the package's syntax trees.
+func findStructASTByName(pkg *packages.Package, name string) (*ast.StructType, error) {
+	for _, file := range pkg.Syntax {
+		var found *ast.StructType
+		ast.Inspect(file, func(n ast.Node) bool {
+			if found != nil {
+				return false
+			}
+			typeSpec, ok := n.(*ast.TypeSpec)
+			if !ok || typeSpec.Name.Name != name {
+				return true
+			}
+			if st, ok := typeSpec.Type.(*ast.StructType); ok {
+				found = st
+			}
+			return true
+		})
+		if found != nil {
+			return found, nil
+		}
+	}
+	return nil, errors.Errorf("could not find struct declaration for %s in package %s", name, pkg.PkgPath)
+}
+
+// structInfoForNamed resolves both the type-checked *types.Struct and the *ast.StructType
+// (for comments) for a named struct type. It loads the package that actually declares the
+// type (via the shared cache) rather than searching whichever package happened to reference
+// it, so a struct shared across templates (e.g. a common ResourceSelector embedded via
+// +inline/+group) resolves correctly even when it lives in a different package than the
+// template currently being processed.
+func structInfoForNamed(cache *packageCache, named *types.Named) (*types.Struct, *ast.StructType, error) {
+	structType, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return nil, nil, errors.Errorf("type %s is not a struct", named.Obj().Name())
+	}
+	declPkg, err := cache.load(named.Obj().Pkg().Path())
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "loading package declaring %s", named.Obj().Name())
+	}
+	astStruct, err := findStructASTByName(declPkg, named.Obj().Name())
+	if err != nil {
+		return nil, nil, err
+	}
+	return structType, astStruct, nil
+}
+
+// constructParameterDescsFromEmbeddedMember expands an embedded (anonymous) struct field
+// into the ParameterDescs it should contribute to its parent. A `+inline` tag promotes the
+// embedded struct's own parameters directly into the parent (relying on Go's normal field
+// promotion for anonymous members so that p.<FieldName> keeps working). A `+group=<name>` tag
+// instead keeps the embedded fields together as a single nested object sub-parameter, so that
+// callers can share a struct like ResourceSelector across many templates without its fields
+// leaking into every parent's top-level namespace.
+func constructParameterDescsFromEmbeddedMember(cache *packageCache, field *types.Var, commentLines []string) ([]check.ParameterDesc, error) {
+	relevantTyp := field.Type()
+	if ptr, ok := relevantTyp.(*types.Pointer); ok {
+		relevantTyp = ptr.Elem()
+	}
+	named, ok := relevantTyp.(*types.Named)
+	if !ok {
+		return nil, errors.Errorf("embedded member %s must be a named struct type to be composed into Params", field.Name())
+	}
+	subStructType, subAST, err := structInfoForNamed(cache, named)
+	if err != nil {
+		return nil, errors.Wrapf(err, "handling embedded member %v", field.Name())
+	}
+	subParamDescs, err := constructParameterDescsFromStruct(cache, subStructType, subAST)
+	if err != nil {
+		return nil, errors.Wrapf(err, "handling embedded member %v", field.Name())
+	}
+
+	extractedTags := extractCommentTags(metadataMarker, commentLines)
+	var inline bool
+	if err := setBoolBasedOnPresenceOfTag(&inline, "inline", extractedTags); err != nil {
+		return nil, err
+	}
+	group := extractedTags["group"]
+
+	switch {
+	case inline && len(group) > 0:
+		return nil, errors.Errorf("embedded member %s cannot have both +inline and +group tags", field.Name())
+	case inline:
+		return subParamDescs, nil
+	case len(group) == 1 && group[0] != "":
+		// Qualify the field path through the embedded member's (implicit) field name so that
+		// a field name shared with a sibling group doesn't resolve ambiguously via promotion.
+		for i := range subParamDescs {
+			subParamDescs[i].XXXStructFieldName = field.Name() + "." + subParamDescs[i].XXXStructFieldName
+		}
+		return []check.ParameterDesc{
+			{
+				Name:               group[0],
+				Description:        getDescription(commentLines),
+				Type:               check.ObjectType,
+				SubParameters:      subParamDescs,
+				XXXStructFieldName: field.Name(),
+			},
+		}, nil
+	default:
+		return nil, errors.Errorf("embedded member %s must have either a +inline or a +group=<name> tag", field.Name())
+	}
+}
+
+func constructParameterDescsFromStruct(cache *packageCache, structType *types.Struct, astStruct *ast.StructType) ([]check.ParameterDesc, error) {
+	astFields := flattenASTFields(astStruct)
+	if len(astFields) != structType.NumFields() {
+		return nil, errors.Errorf("mismatched field count between type info (%d) and source (%d)", structType.NumFields(), len(astFields))
+	}
+
 	var paramDescs []check.ParameterDesc
-	for _, member := range typeSpec.Members {
-		if member.Embedded {
-			return nil, errors.Errorf("cannot handle embedded member %s in %+v", member.Name, typeSpec)
+	seenNames := set.NewStringSet()
+	for i := 0; i < structType.NumFields(); i++ {
+		field := structType.Field(i)
+		commentLines := commentGroupLines(astFields[i].doc)
+
+		if field.Embedded() {
+			embeddedDescs, err := constructParameterDescsFromEmbeddedMember(cache, field, commentLines)
+			if err != nil {
+				return nil, err
+			}
+			for _, embeddedDesc := range embeddedDescs {
+				if seenNames.Add(embeddedDesc.Name) {
+					paramDescs = append(paramDescs, embeddedDesc)
+				}
+			}
+			continue
 		}
 
 		desc := check.ParameterDesc{
-			Name:               getName(member),
-			Description:        getDescription(member),
-			XXXStructFieldName: member.Name,
+			Name:               getName(field.Name(), reflect.StructTag(structType.Tag(i))),
+			Description:        getDescription(commentLines),
+			XXXStructFieldName: field.Name(),
 		}
-		relevantTyp := member.Type
-		if relevantTyp.Kind == types.Pointer {
+
+		relevantTyp := field.Type()
+		if ptr, ok := relevantTyp.(*types.Pointer); ok {
 			desc.XXXIsPointer = true
-			relevantTyp = relevantTyp.Elem
+			relevantTyp = ptr.Elem()
 		}
-		switch kind := relevantTyp.Kind; kind {
-		case types.Builtin:
-			checkType, err := getCheckTypeFromParsedBuiltinType(relevantTyp)
+
+		switch underlying := relevantTyp.Underlying().(type) {
+		case *types.Basic:
+			checkType, format, err := getCheckTypeFromGoType(relevantTyp)
 			if err != nil {
-				return nil, errors.Wrapf(err, "handling field %v", member.Name)
+				return nil, errors.Wrapf(err, "handling field %v", field.Name())
 			}
 			desc.Type = checkType
-		case types.Slice:
+			desc.Format = format
+		case *types.Slice:
 			desc.Type = check.ArrayType
 			// For now we only support array of builtin types. No array of objects or array of arrays.
-			elemType, err := getCheckTypeFromParsedBuiltinType(member.Type.Elem)
+			elemType, _, err := getCheckTypeFromGoType(underlying.Elem())
 			if err != nil {
-				return nil, errors.Wrapf(err, "handling array elem type %v", member.Type.Elem)
+				return nil, errors.Wrapf(err, "handling array elem type for field %v", field.Name())
 			}
 			desc.ArrayElemType = elemType
-		case types.Struct:
+		case *types.Map:
+			keyType, _, err := getCheckTypeFromGoType(underlying.Key())
+			if err != nil || keyType != check.StringType {
+				return nil, errors.Errorf("handling field %v: only string-keyed maps are supported", field.Name())
+			}
+			valueType, _, err := getCheckTypeFromGoType(underlying.Elem())
+			if err != nil {
+				return nil, errors.Wrapf(err, "handling map value type for field %v", field.Name())
+			}
+			desc.Type = check.ObjectType
+			desc.AdditionalProperties = &check.ParameterDesc{Type: valueType}
+		case *types.Struct:
+			named, ok := relevantTyp.(*types.Named)
+			if !ok {
+				return nil, errors.Errorf("handling field %v: anonymous struct fields are not supported, declare a named type", field.Name())
+			}
+			subStructType, subAST, err := structInfoForNamed(cache, named)
+			if err != nil {
+				return nil, errors.Wrapf(err, "handling field %v", field.Name())
+			}
 			desc.Type = check.ObjectType
-			subParams, err := constructParameterDescsFromStruct(member.Type)
+			subParams, err := constructParameterDescsFromStruct(cache, subStructType, subAST)
 			if err != nil {
-				return nil, errors.Wrapf(err, "handling field %v", member.Name)
+				return nil, errors.Wrapf(err, "handling field %v", field.Name())
+			}
+			for j := range subParams {
+				subParams[j].XXXStructFieldName = field.Name() + "." + subParams[j].XXXStructFieldName
 			}
 			desc.SubParameters = subParams
 		default:
-			return nil, errors.Errorf("currently unsupported type %v", member.Type)
+			return nil, errors.Errorf("handling field %v: currently unsupported type %v", field.Name(), relevantTyp)
 		}
 
-		extractedTags := types.ExtractCommentTags(metadataMarker, member.CommentLines)
+		extractedTags := extractCommentTags(metadataMarker, commentLines)
 		desc.Examples = extractedTags["example"]
 		desc.Enum = extractedTags["enum"]
 		if err := setBoolBasedOnPresenceOfTag(&desc.Required, "required", extractedTags); err != nil {
@@ -229,52 +738,314 @@ func constructParameterDescsFromStruct(typeSpec *types.Type) ([]check.ParameterD
 		if err := setBoolBasedOnPresenceOfTag(&desc.NotNegatable, "notnegatable", extractedTags); err != nil {
 			return nil, err
 		}
-		paramDescs = append(paramDescs, desc)
+		if err := setStringBasedOnValueOfTag(&desc.Pattern, "pattern", extractedTags); err != nil {
+			return nil, err
+		}
+		if err := setFloatPtrBasedOnValueOfTag(&desc.Min, "min", extractedTags); err != nil {
+			return nil, err
+		}
+		if err := setFloatPtrBasedOnValueOfTag(&desc.Max, "max", extractedTags); err != nil {
+			return nil, err
+		}
+		if err := setIntPtrBasedOnValueOfTag(&desc.MinLength, "minLength", extractedTags); err != nil {
+			return nil, err
+		}
+		if err := setIntPtrBasedOnValueOfTag(&desc.MaxLength, "maxLength", extractedTags); err != nil {
+			return nil, err
+		}
+		if err := setBoolBasedOnPresenceOfTag(&desc.UniqueItems, "uniqueItems", extractedTags); err != nil {
+			return nil, err
+		}
+		if err := setStringBasedOnValueOfTag(&desc.Format, "format", extractedTags); err != nil {
+			return nil, err
+		}
+		if err := setStringBasedOnValueOfTag(&desc.Default, "default", extractedTags); err != nil {
+			return nil, err
+		}
+		if seenNames.Add(desc.Name) {
+			paramDescs = append(paramDescs, desc)
+		}
 	}
 	return paramDescs, nil
 }
 
-func getCheckTypeFromParsedBuiltinType(typeSpec * types.Type) (check.ParameterType, error) {
-	switch typeSpec {
-	case types.String:
-		return check.StringType, nil
-	case types.Int:
-		return check.IntegerType, nil
-	case types.Float32, types.Float64:
-		return check.NumberType, nil
-	case types.Bool:
-		return check.BooleanType, nil
+// flattenParamDescsForValidation expands object-typed parameters backed by a struct (an
+// embedded +group struct or a struct-typed field) into their individual leaf parameters,
+// recursing into SubParameters. Those leaves' XXXStructFieldName was already qualified with
+// the path through their parent by constructParameterDescsFromEmbeddedMember or
+// constructParameterDescsFromStruct (e.g. "ResourceSelector.Kind"), so once flattened
+// they can be validated exactly like any other top-level field. Object-typed parameters that
+// instead come from a map (AdditionalProperties set, no SubParameters to recurse into) are
+// passed through unchanged, since Validate() doesn't yet know how to validate those generically.
+func flattenParamDescsForValidation(descs []check.ParameterDesc) []check.ParameterDesc {
+	var out []check.ParameterDesc
+	for _, desc := range descs {
+		if desc.Type == check.ObjectType && desc.AdditionalProperties == nil {
+			out = append(out, flattenParamDescsForValidation(desc.SubParameters)...)
+			continue
+		}
+		out = append(out, desc)
+	}
+	return out
+}
+
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// paramTypeToJSONSchemaType translates a check.ParameterType into the corresponding
+// JSON Schema "type" keyword.
+func paramTypeToJSONSchemaType(typ check.ParameterType) (string, error) {
+	switch typ {
+	case check.StringType:
+		return "string", nil
+	case check.IntegerType:
+		return "integer", nil
+	case check.NumberType:
+		return "number", nil
+	case check.BooleanType:
+		return "boolean", nil
+	case check.ArrayType:
+		return "array", nil
+	case check.ObjectType:
+		return "object", nil
 	default:
-		return "",  errors.Errorf("currently unsupported type %v", typeSpec)
+		return "", errors.Errorf("no JSON Schema type known for parameter type %v", typ)
 	}
 }
 
-func processTemplate(dir string) error {
-	b := parser.New()
-	// This avoids parsing generated files in the package (since we add +build !templatecodegen to them,
-	// which makes the parsing much quicker since the parser doesn't have to load any imported packages).
-	b.AddBuildTags("templatecodegen")
-	if err := b.AddDir(fmt.Sprintf("./%s/internal/params", dir)); err != nil {
-		return err
+// paramDescToJSONSchema converts a single check.ParameterDesc into a JSON Schema
+// (draft-07) fragment, recursing into SubParameters for object types, into
+// AdditionalProperties for map-typed object parameters, and into ArrayElemType for
+// array types.
+func paramDescToJSONSchema(desc check.ParameterDesc) (map[string]interface{}, error) {
+	schemaType, err := paramTypeToJSONSchemaType(desc.Type)
+	if err != nil {
+		return nil, errors.Wrapf(err, "param %s", desc.Name)
+	}
+	schema := map[string]interface{}{"type": schemaType}
+	if desc.Description != "" {
+		schema["description"] = desc.Description
+	}
+	if len(desc.Enum) > 0 {
+		schema["enum"] = desc.Enum
+	}
+	if len(desc.Examples) > 0 {
+		schema["examples"] = desc.Examples
+	}
+	if desc.Pattern != "" {
+		schema["pattern"] = desc.Pattern
+	}
+	if desc.Format != "" {
+		schema["format"] = desc.Format
+	}
+	if desc.Min != nil {
+		schema["minimum"] = *desc.Min
+	}
+	if desc.Max != nil {
+		schema["maximum"] = *desc.Max
 	}
-	typeUniverse, err := b.FindTypes()
+	if desc.MinLength != nil {
+		schema["minLength"] = *desc.MinLength
+	}
+	if desc.MaxLength != nil {
+		schema["maxLength"] = *desc.MaxLength
+	}
+	if desc.UniqueItems {
+		schema["uniqueItems"] = true
+	}
+	switch desc.Type {
+	case check.ObjectType:
+		if desc.AdditionalProperties != nil {
+			additionalSchema, err := paramDescToJSONSchema(*desc.AdditionalProperties)
+			if err != nil {
+				return nil, errors.Wrapf(err, "param %s additionalProperties", desc.Name)
+			}
+			schema["additionalProperties"] = additionalSchema
+			break
+		}
+		properties := make(map[string]interface{}, len(desc.SubParameters))
+		var required []string
+		for _, sub := range desc.SubParameters {
+			subSchema, err := paramDescToJSONSchema(sub)
+			if err != nil {
+				return nil, err
+			}
+			properties[sub.Name] = subSchema
+			if sub.Required {
+				required = append(required, sub.Name)
+			}
+		}
+		schema["properties"] = properties
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+	case check.ArrayType:
+		elemType, err := paramTypeToJSONSchemaType(desc.ArrayElemType)
+		if err != nil {
+			return nil, errors.Wrapf(err, "param %s elem type", desc.Name)
+		}
+		schema["items"] = map[string]interface{}{"type": elemType}
+	}
+	return schema, nil
+}
+
+// templateParamsToJSONSchema builds the top-level JSON Schema document for a template's
+// Params struct, keying each parameter by name under "properties".
+func templateParamsToJSONSchema(paramDescs []check.ParameterDesc) (map[string]interface{}, error) {
+	properties := make(map[string]interface{}, len(paramDescs))
+	var required []string
+	for _, desc := range paramDescs {
+		paramSchema, err := paramDescToJSONSchema(desc)
+		if err != nil {
+			return nil, err
+		}
+		properties[desc.Name] = paramSchema
+		if desc.Required {
+			required = append(required, desc.Name)
+		}
+	}
+	schema := map[string]interface{}{
+		"$schema":              jsonSchemaDraft,
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema, nil
+}
+
+func writeJSONFile(outFileName string, obj interface{}) error {
+	buf := bytes.NewBuffer(nil)
+	enc := json.NewEncoder(buf)
+	enc.SetIndent("", "\t")
+	if err := enc.Encode(obj); err != nil {
+		return errors.Wrapf(err, "marshalling %s", outFileName)
+	}
+	return ioutil.WriteFile(outFileName, buf.Bytes(), 0644)
+}
+
+// pkgDocComment returns the Godoc comment attached to the package clause, if any, to use
+// as a human-readable header for the template's generated documentation.
+func pkgDocComment(pkg *packages.Package) string {
+	for _, file := range pkg.Syntax {
+		if file.Doc != nil {
+			if text := strings.TrimSpace(file.Doc.Text()); text != "" {
+				return text
+			}
+		}
+	}
+	return ""
+}
+
+// paramDescMarkdownRow renders a single parameter as one row of the parameter reference table.
+func paramDescMarkdownRow(desc check.ParameterDesc) string {
+	required := ""
+	if desc.Required {
+		required = "yes"
+	}
+	return fmt.Sprintf("| `%s` | %s | %s | %s | %s | %s | %s |",
+		desc.Name,
+		desc.Type,
+		required,
+		strings.Join(desc.Enum, ", "),
+		strings.Join(desc.Examples, ", "),
+		desc.Default,
+		strings.ReplaceAll(desc.Description, "\n", " "),
+	)
+}
+
+// templateParamsToMarkdown renders the Markdown parameter reference for a single template,
+// in field declaration order, headed by the directory/template name and followed by the
+// template's own package doc comment when it has one.
+func templateParamsToMarkdown(templateName, pkgDoc string, paramDescs []check.ParameterDesc) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("## %s\n\n", templateName))
+	if pkgDoc != "" {
+		sb.WriteString(pkgDoc)
+		sb.WriteString("\n\n")
+	}
+	if len(paramDescs) == 0 {
+		sb.WriteString("This template takes no parameters.\n\n")
+		return sb.String()
+	}
+	sb.WriteString("| Name | Type | Required | Enum | Examples | Default | Description |\n")
+	sb.WriteString("| --- | --- | --- | --- | --- | --- | --- |\n")
+	for _, desc := range paramDescs {
+		sb.WriteString(paramDescMarkdownRow(desc))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// packageCache memoizes loaded packages across concurrent processTemplate calls, since
+// templates commonly embed a params struct shared from a common internal package.
+type packageCache struct {
+	mu   sync.Mutex
+	pkgs map[string]*packages.Package
+}
+
+func newPackageCache() *packageCache {
+	return &packageCache{pkgs: make(map[string]*packages.Package)}
+}
+
+func (c *packageCache) load(pkgPath string) (*packages.Package, error) {
+	c.mu.Lock()
+	if pkg, ok := c.pkgs[pkgPath]; ok {
+		c.mu.Unlock()
+		return pkg, nil
+	}
+	c.mu.Unlock()
+
+	loaded, err := packages.Load(&packages.Config{Mode: packagesLoadMode}, pkgPath)
 	if err != nil {
-		return err
+		return nil, errors.Wrapf(err, "loading package %s", pkgPath)
+	}
+	if len(loaded) != 1 {
+		return nil, errors.Errorf("found unexpected number of packages for %s: %d", pkgPath, len(loaded))
 	}
-	pkgNames := b.FindPackages()
-	if len(pkgNames) != 1 {
-		return errors.Errorf("found unexpected number of packages in %+v: %d", pkgNames, len(pkgNames))
+	pkg := loaded[0]
+	if len(pkg.Errors) > 0 {
+		return nil, errors.Errorf("errors loading package %s: %v", pkgPath, pkg.Errors)
 	}
 
-	pkg := typeUniverse.Package(pkgNames[0])
-	paramsType := pkg.Type(paramsStructName)
+	c.mu.Lock()
+	c.pkgs[pkgPath] = pkg
+	c.mu.Unlock()
+	return pkg, nil
+}
+
+// templateResult bundles everything processTemplate produces, for mainCmd to aggregate
+// into the cross-template schema store and parameter reference once every directory has
+// finished processing.
+type templateResult struct {
+	Schema   map[string]interface{}
+	Markdown string
+}
+
+func processTemplate(cache *packageCache, dir string) (templateResult, error) {
+	pkg, err := cache.load(fmt.Sprintf("./%s/internal/params", dir))
+	if err != nil {
+		return templateResult{}, err
+	}
 
-	if paramsType.Kind != types.Struct {
-		return errors.Errorf("unexpected param type: %+v", paramsType)
+	paramsObj := pkg.Types.Scope().Lookup(paramsStructName)
+	if paramsObj == nil {
+		return templateResult{}, errors.Errorf("no %s type found in package %s", paramsStructName, pkg.PkgPath)
 	}
-	paramDescs, err := constructParameterDescsFromStruct(paramsType)
+	named, ok := paramsObj.Type().(*types.Named)
+	if !ok {
+		return templateResult{}, errors.Errorf("unexpected %s type: %v", paramsStructName, paramsObj.Type())
+	}
+	paramsStructType, paramsAST, err := structInfoForNamed(cache, named)
 	if err != nil {
-		return err
+		return templateResult{}, errors.Wrapf(err, "resolving %s", paramsStructName)
+	}
+
+	paramDescs, err := constructParameterDescsFromStruct(cache, paramsStructType, paramsAST)
+	if err != nil {
+		return templateResult{}, err
 	}
 
 	var templateObj []templateElem
@@ -284,7 +1055,7 @@ func processTemplate(dir string) error {
 		enc := json.NewEncoder(buf)
 		enc.SetIndent("", "\t")
 		if err := enc.Encode(paramDesc); err != nil {
-			return errors.Wrapf(err, "couldn't marshal param %v", paramDesc)
+			return templateResult{}, errors.Wrapf(err, "couldn't marshal param %v", paramDesc)
 		}
 
 		templateObj = append(templateObj, templateElem{
@@ -293,33 +1064,107 @@ func processTemplate(dir string) error {
 		})
 	}
 
+	var validateTemplateObj []templateElem
+	for _, paramDesc := range flattenParamDescsForValidation(paramDescs) {
+		validateTemplateObj = append(validateTemplateObj, templateElem{ParamDesc: paramDesc})
+	}
+
 	outFileName := filepath.Join(dir, "internal", "params", "gen-params.go")
 	outF, err := os.Create(outFileName)
 	if err != nil {
-		return errors.Wrap(err, "creating output file")
+		return templateResult{}, errors.Wrap(err, "creating output file")
 	}
 	defer utils.IgnoreError(outF.Close)
-	if err := fileTemplate.Execute(outF, templateObj); err != nil {
-		return err
+	data := fileTemplateData{Params: templateObj, ValidateParams: validateTemplateObj}
+	if err := fileTemplate.Execute(outF, data); err != nil {
+		return templateResult{}, err
 	}
-	return nil
+
+	schema, err := templateParamsToJSONSchema(paramDescs)
+	if err != nil {
+		return templateResult{}, errors.Wrap(err, "building JSON schema")
+	}
+	schemaFileName := filepath.Join(*schemaOut, dir, "internal", "params", "params.schema.json")
+	if err := os.MkdirAll(filepath.Dir(schemaFileName), 0755); err != nil {
+		return templateResult{}, errors.Wrap(err, "creating schema output directory")
+	}
+	if err := writeJSONFile(schemaFileName, schema); err != nil {
+		return templateResult{}, errors.Wrap(err, "writing params.schema.json")
+	}
+
+	markdown := templateParamsToMarkdown(dir, pkgDocComment(pkg), paramDescs)
+
+	return templateResult{Schema: schema, Markdown: markdown}, nil
 }
 
 func mainCmd() error {
+	flag.Parse()
 	fileInfos, err := ioutil.ReadDir(".")
 	if err != nil {
 		return err
 	}
+
+	var dirs []string
 	for _, fileInfo := range fileInfos {
-		if !fileInfo.IsDir() {
+		if !fileInfo.IsDir() || knownNonTemplateDirs.Contains(fileInfo.Name()) {
 			continue
 		}
-		if knownNonTemplateDirs.Contains(fileInfo.Name()) {
-			continue
-		}
-		if err := processTemplate(fileInfo.Name()); err != nil {
-			return errors.Wrapf(err, "processing dir %v", fileInfo.Name())
-		}
+		dirs = append(dirs, fileInfo.Name())
+	}
+
+	cache := newPackageCache()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		results  = make(map[string]templateResult, len(dirs))
+		firstErr error
+	)
+	for _, dir := range dirs {
+		dir := dir
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := processTemplate(cache, dir)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = errors.Wrapf(err, "processing dir %v", dir)
+				}
+				return
+			}
+			results[dir] = result
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+
+	templateSchemas := make(map[string]interface{}, len(dirs))
+	var docs strings.Builder
+	docs.WriteString("# Template parameter reference\n\n")
+	for _, dir := range dirs {
+		templateSchemas[dir] = results[dir].Schema
+		docs.WriteString(results[dir].Markdown)
+	}
+
+	templatesSchemaFileName := filepath.Join(*schemaOut, "templates.schema.json")
+	if err := os.MkdirAll(filepath.Dir(templatesSchemaFileName), 0755); err != nil {
+		return errors.Wrap(err, "creating schema output directory")
+	}
+	if err := writeJSONFile(templatesSchemaFileName, templateSchemas); err != nil {
+		return errors.Wrap(err, "writing templates.schema.json")
+	}
+
+	docsFileName := filepath.Join(*docsOut, "templates.md")
+	if err := os.MkdirAll(filepath.Dir(docsFileName), 0755); err != nil {
+		return errors.Wrap(err, "creating docs output directory")
+	}
+	if err := ioutil.WriteFile(docsFileName, []byte(docs.String()), 0644); err != nil {
+		return errors.Wrap(err, "writing templates.md")
 	}
 	return nil
 }
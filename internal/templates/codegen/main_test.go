@@ -0,0 +1,166 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"golang.stackrox.io/kube-linter/internal/check"
+)
+
+func TestFlattenParamDescsForValidation(t *testing.T) {
+	min := 1.0
+	descs := []check.ParameterDesc{
+		{Name: "name", Type: check.StringType, XXXStructFieldName: "Name"},
+		{
+			Name: "selector",
+			Type: check.ObjectType,
+			SubParameters: []check.ParameterDesc{
+				{Name: "kind", Type: check.StringType, Required: true, XXXStructFieldName: "Selector.Kind"},
+				{Name: "replicas", Type: check.IntegerType, Min: &min, XXXStructFieldName: "Selector.Replicas"},
+			},
+			XXXStructFieldName: "Selector",
+		},
+		{
+			Name:                 "labels",
+			Type:                 check.ObjectType,
+			AdditionalProperties: &check.ParameterDesc{Type: check.StringType},
+			XXXStructFieldName:   "Labels",
+		},
+	}
+
+	flattened := flattenParamDescsForValidation(descs)
+	if len(flattened) != 4 {
+		t.Fatalf("expected 4 flattened params, got %d: %+v", len(flattened), flattened)
+	}
+
+	var gotNames []string
+	for _, desc := range flattened {
+		gotNames = append(gotNames, desc.Name)
+	}
+	wantNames := []string{"name", "kind", "replicas", "labels"}
+	for i, want := range wantNames {
+		if gotNames[i] != want {
+			t.Errorf("flattened[%d].Name = %q, want %q (full order: %v)", i, gotNames[i], want, gotNames)
+		}
+	}
+
+	// The +group struct's own ObjectType wrapper must not survive flattening...
+	for _, desc := range flattened {
+		if desc.Name == "selector" {
+			t.Errorf("expected the +group wrapper param itself to be replaced by its leaves, found it in %+v", flattened)
+		}
+	}
+	// ...but a genuine map (AdditionalProperties, no SubParameters) must be left as a leaf,
+	// since Validate() still has no generic way to check it.
+	if flattened[3].Type != check.ObjectType || flattened[3].AdditionalProperties == nil {
+		t.Errorf("expected the map-typed param to survive flattening as an object leaf, got %+v", flattened[3])
+	}
+}
+
+// TestValidateHandlesGroupedObjectFields renders the generated Validate() body for a template
+// whose Params embeds a +group'd shared struct, and checks that a required, grouped field
+// produces the expected empty-string check rather than the object-typed params unconditionally
+// returning an "not yet supported" error (regression test for the bug where any template
+// using +group could never pass validation).
+func TestValidateHandlesGroupedObjectFields(t *testing.T) {
+	kindRequired := check.ParameterDesc{
+		Name:               "kind",
+		Type:               check.StringType,
+		Required:           true,
+		XXXStructFieldName: "Selector.Kind",
+	}
+	data := fileTemplateData{
+		Params: []templateElem{
+			{ParamDesc: check.ParameterDesc{
+				Name: "selector",
+				Type: check.ObjectType,
+				SubParameters: []check.ParameterDesc{
+					kindRequired,
+				},
+				XXXStructFieldName: "Selector",
+			}},
+		},
+		ValidateParams: []templateElem{
+			{ParamDesc: kindRequired},
+		},
+	}
+
+	var sb strings.Builder
+	if err := fileTemplate.Execute(&sb, data); err != nil {
+		t.Fatalf("executing fileTemplate: %v", err)
+	}
+	generated := sb.String()
+
+	if strings.Contains(generated, "not yet supported for object type") {
+		t.Errorf("generated Validate() still unconditionally rejects the object-typed param; got:\n%s", generated)
+	}
+	if !strings.Contains(generated, `if p.Selector.Kind == "" {`) {
+		t.Errorf("generated Validate() does not check the grouped required field p.Selector.Kind; got:\n%s", generated)
+	}
+}
+
+// TestValidateRequiredBoolAndArrayEnum renders Validate() for a required boolean param and an
+// enum-constrained array param, and checks that both get real validation code instead of the
+// "required parameter validation is not supported" / "enum validation is not supported" errors
+// that previously fired unconditionally for these types.
+func TestValidateRequiredBoolAndArrayEnum(t *testing.T) {
+	boolRequired := check.ParameterDesc{
+		Name:               "enabled",
+		Type:               check.BooleanType,
+		Required:           true,
+		XXXStructFieldName: "Enabled",
+	}
+	arrayEnum := check.ParameterDesc{
+		Name:               "modes",
+		Type:               check.ArrayType,
+		ArrayElemType:      check.StringType,
+		Enum:               []string{"ro", "rw"},
+		XXXStructFieldName: "Modes",
+	}
+	data := fileTemplateData{
+		Params:         []templateElem{{ParamDesc: boolRequired}, {ParamDesc: arrayEnum}},
+		ValidateParams: []templateElem{{ParamDesc: boolRequired}, {ParamDesc: arrayEnum}},
+	}
+
+	var sb strings.Builder
+	if err := fileTemplate.Execute(&sb, data); err != nil {
+		t.Fatalf("executing fileTemplate: %v", err)
+	}
+	generated := sb.String()
+
+	if strings.Contains(generated, "required parameter validation is not supported") {
+		t.Errorf("generated Validate() still rejects required bool params; got:\n%s", generated)
+	}
+	if !strings.Contains(generated, "if !p.Enabled {") {
+		t.Errorf("generated Validate() does not check the required bool field p.Enabled; got:\n%s", generated)
+	}
+	if !strings.Contains(generated, "for _, elemValue := range p.Modes {") {
+		t.Errorf("generated Validate() does not validate each element of the enum-constrained array p.Modes; got:\n%s", generated)
+	}
+}
+
+// TestNewParamsAppliesArrayDefault renders NewParams() for an array-typed +default param and
+// checks that the default is actually assigned, rather than being silently dropped the way it
+// used to be for every array-typed field regardless of whether a default was tagged.
+func TestNewParamsAppliesArrayDefault(t *testing.T) {
+	modes := check.ParameterDesc{
+		Name:               "modes",
+		Type:               check.ArrayType,
+		ArrayElemType:      check.StringType,
+		Default:            `[]string{"ro"}`,
+		XXXStructFieldName: "Modes",
+	}
+	data := fileTemplateData{
+		Params: []templateElem{{ParamDesc: modes}},
+	}
+
+	var sb strings.Builder
+	if err := fileTemplate.Execute(&sb, data); err != nil {
+		t.Fatalf("executing fileTemplate: %v", err)
+	}
+	generated := sb.String()
+
+	if !strings.Contains(generated, `p.Modes = []string{"ro"}`) {
+		t.Errorf("generated NewParams() does not apply the array default to p.Modes; got:\n%s", generated)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"golang.stackrox.io/kube-linter/internal/check"
+)
+
+func TestParamDescMarkdownRow(t *testing.T) {
+	row := paramDescMarkdownRow(check.ParameterDesc{
+		Name:        "mode",
+		Type:        check.StringType,
+		Required:    true,
+		Enum:        []string{"ro", "rw"},
+		Examples:    []string{"ro"},
+		Default:     "ro",
+		Description: "the\naccess mode",
+	})
+
+	for _, want := range []string{"`mode`", "yes", "ro, rw", "the access mode"} {
+		if !strings.Contains(row, want) {
+			t.Errorf("row %q does not contain %q", row, want)
+		}
+	}
+	if strings.Contains(row, "\n") {
+		t.Errorf("row must be a single line, got %q", row)
+	}
+}
+
+func TestTemplateParamsToMarkdownNoParams(t *testing.T) {
+	md := templateParamsToMarkdown("no-params-template", "", nil)
+	if !strings.Contains(md, "## no-params-template") {
+		t.Errorf("expected a header for the template name, got:\n%s", md)
+	}
+	if !strings.Contains(md, "takes no parameters") {
+		t.Errorf("expected the no-parameters note, got:\n%s", md)
+	}
+}
+
+func TestTemplateParamsToMarkdownIncludesPkgDocAndFieldOrder(t *testing.T) {
+	md := templateParamsToMarkdown("my-template", "My template does a thing.", []check.ParameterDesc{
+		{Name: "first", Type: check.StringType},
+		{Name: "second", Type: check.IntegerType},
+	})
+
+	if !strings.Contains(md, "My template does a thing.") {
+		t.Errorf("expected the package doc comment to be included, got:\n%s", md)
+	}
+	firstIdx := strings.Index(md, "`first`")
+	secondIdx := strings.Index(md, "`second`")
+	if firstIdx == -1 || secondIdx == -1 || firstIdx > secondIdx {
+		t.Errorf("expected params in declaration order (first before second), got:\n%s", md)
+	}
+}
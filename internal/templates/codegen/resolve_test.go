@@ -0,0 +1,73 @@
+package main
+
+import (
+	"testing"
+
+	"go/types"
+
+	"golang.stackrox.io/kube-linter/internal/check"
+)
+
+// TestStructInfoForNamedResolvesEmbeddedMemberViaDeclaringPackage loads two real, on-disk
+// packages through the actual go/packages.Load path used by processTemplate: testdata/fixtures/shared,
+// which declares ResourceSelector, and testdata/fixtures/parent, whose Params struct embeds it
+// via +inline. This is a regression test for the bug where structInfoForNamed searched
+// whichever package happened to reference a named struct, rather than the package that
+// actually declares it, and so failed to resolve a struct embedded from a different package
+// than the one currently being processed.
+func TestStructInfoForNamedResolvesEmbeddedMemberViaDeclaringPackage(t *testing.T) {
+	cache := newPackageCache()
+
+	pkg, err := cache.load("golang.stackrox.io/kube-linter/internal/templates/codegen/testdata/fixtures/parent")
+	if err != nil {
+		t.Fatalf("loading parent package: %v", err)
+	}
+
+	paramsObj := pkg.Types.Scope().Lookup(paramsStructName)
+	if paramsObj == nil {
+		t.Fatalf("no %s type found in package %s", paramsStructName, pkg.PkgPath)
+	}
+	named, ok := paramsObj.Type().(*types.Named)
+	if !ok {
+		t.Fatalf("unexpected %s type: %v", paramsStructName, paramsObj.Type())
+	}
+
+	structType, astStruct, err := structInfoForNamed(cache, named)
+	if err != nil {
+		t.Fatalf("structInfoForNamed: %v", err)
+	}
+
+	descs, err := constructParameterDescsFromStruct(cache, structType, astStruct)
+	if err != nil {
+		t.Fatalf("constructParameterDescsFromStruct: %v", err)
+	}
+
+	var names []string
+	for _, desc := range descs {
+		names = append(names, desc.Name)
+	}
+
+	assertContainsParam(t, descs, "kind", check.StringType, "Kind")
+	assertContainsParam(t, descs, "name", check.StringType, "Name")
+
+	if len(descs) != 2 {
+		t.Errorf("expected exactly 2 params (the +inline-promoted kind, and name), got %v", names)
+	}
+}
+
+func assertContainsParam(t *testing.T, descs []check.ParameterDesc, name string, typ check.ParameterType, structField string) {
+	t.Helper()
+	for _, desc := range descs {
+		if desc.Name != name {
+			continue
+		}
+		if desc.Type != typ {
+			t.Errorf("param %q: got type %v, want %v", name, desc.Type, typ)
+		}
+		if desc.XXXStructFieldName != structField {
+			t.Errorf("param %q: got struct field %v, want %v", name, desc.XXXStructFieldName, structField)
+		}
+		return
+	}
+	t.Errorf("expected a param named %q, got none", name)
+}
@@ -0,0 +1,14 @@
+// Package parent embeds a shared struct declared in another package via +inline, as codegen
+// test fixture data (see resolve_test.go).
+package parent
+
+import "golang.stackrox.io/kube-linter/internal/templates/codegen/testdata/fixtures/shared"
+
+// Params is a template's parameters, sharing its selector fields with other templates.
+type Params struct {
+	// +inline
+	shared.ResourceSelector
+
+	// Name identifies the object to check.
+	Name string
+}
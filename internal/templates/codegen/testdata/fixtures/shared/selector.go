@@ -0,0 +1,9 @@
+// Package shared declares a struct shared across template Params via +inline/+group, for use
+// as codegen test fixture data (see resolve_test.go).
+package shared
+
+// ResourceSelector selects Kubernetes resources by kind.
+type ResourceSelector struct {
+	// Kind is the resource kind to select.
+	Kind string
+}
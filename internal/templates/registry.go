@@ -0,0 +1,36 @@
+// Package templates holds the registry of check templates, keyed by name, that each
+// template's init() populates via Register, and that config loading and testutil look
+// templates up from by name.
+package templates
+
+import "golang.stackrox.io/kube-linter/internal/check"
+
+// Template is a registered check template: given this template's raw config, ParseAndValidate
+// decodes and validates it into the template's typed Params, and Instantiate turns those Params
+// into a check.Func.
+type Template struct {
+	Name             string
+	ParseAndValidate func(map[string]interface{}) (interface{}, error)
+	Instantiate      func(interface{}) (check.Func, error)
+}
+
+var registry = make(map[string]Template)
+
+// Register adds tpl to the registry. It panics on a duplicate name, since that always
+// indicates two templates fighting over the same registration rather than a runtime
+// condition callers could reasonably recover from.
+func Register(tpl Template) {
+	if _, ok := registry[tpl.Name]; ok {
+		panic("template " + tpl.Name + " already registered")
+	}
+	registry[tpl.Name] = tpl
+}
+
+// Get looks up a registered template by name.
+func Get(name string) (*Template, bool) {
+	tpl, ok := registry[name]
+	if !ok {
+		return nil, false
+	}
+	return &tpl, true
+}
@@ -0,0 +1,58 @@
+// Package testutil provides helpers for template unit tests, letting them instantiate a
+// template's check.Func from the same map[string]interface{} shape a real .kube-linter.yaml
+// config produces, instead of hand-rolling a Params{} literal and separately wiring it
+// through the check registry.
+package testutil
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.stackrox.io/kube-linter/internal/check"
+	"golang.stackrox.io/kube-linter/internal/templates"
+)
+
+// InstantiateFromMap looks up the named template, decodes params the same way a real config
+// file would via ParseAndValidate, and instantiates its check.Func, failing t immediately on
+// any error along the way.
+func InstantiateFromMap(t testing.TB, templateName string, params map[string]interface{}) check.Func {
+	t.Helper()
+	tmpl, ok := templates.Get(templateName)
+	if !ok {
+		t.Fatalf("no template registered with name %q", templateName)
+	}
+
+	parsedParams, err := tmpl.ParseAndValidate(params)
+	if err != nil {
+		t.Fatalf("parsing and validating params for template %q: %v", templateName, err)
+	}
+
+	checkFunc, err := tmpl.Instantiate(parsedParams)
+	if err != nil {
+		t.Fatalf("instantiating template %q: %v", templateName, err)
+	}
+	return checkFunc
+}
+
+// AssertParseAndValidateRoundTrips asserts that parsing the same params map twice through
+// ParseAndValidate yields equal results, which is the property templates rely on when their
+// config is decoded from YAML/JSON via mapstructure.
+func AssertParseAndValidateRoundTrips(t testing.TB, templateName string, params map[string]interface{}) {
+	t.Helper()
+	tmpl, ok := templates.Get(templateName)
+	if !ok {
+		t.Fatalf("no template registered with name %q", templateName)
+	}
+
+	first, err := tmpl.ParseAndValidate(params)
+	if err != nil {
+		t.Fatalf("parsing and validating params for template %q: %v", templateName, err)
+	}
+	second, err := tmpl.ParseAndValidate(params)
+	if err != nil {
+		t.Fatalf("parsing and validating params for template %q: %v", templateName, err)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Fatalf("ParseAndValidate is not idempotent for template %q: %+v != %+v", templateName, first, second)
+	}
+}
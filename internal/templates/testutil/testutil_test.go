@@ -0,0 +1,45 @@
+package testutil
+
+import (
+	"testing"
+
+	"golang.stackrox.io/kube-linter/internal/check"
+	"golang.stackrox.io/kube-linter/internal/templates"
+)
+
+type fakeParams struct {
+	Name string
+}
+
+func init() {
+	templates.Register(templates.Template{
+		Name: "testutil-fake",
+		ParseAndValidate: func(m map[string]interface{}) (interface{}, error) {
+			name, _ := m["name"].(string)
+			return fakeParams{Name: name}, nil
+		},
+		Instantiate: func(params interface{}) (check.Func, error) {
+			p := params.(fakeParams)
+			return func(object interface{}) (bool, string) {
+				return object == p.Name, "name mismatch"
+			}, nil
+		},
+	})
+}
+
+func TestInstantiateFromMap(t *testing.T) {
+	checkFunc := InstantiateFromMap(t, "testutil-fake", map[string]interface{}{"name": "my-object"})
+
+	ok, _ := checkFunc("my-object")
+	if !ok {
+		t.Error("expected check.Func to match the configured name")
+	}
+	ok, _ = checkFunc("other-object")
+	if ok {
+		t.Error("expected check.Func not to match a different name")
+	}
+}
+
+func TestAssertParseAndValidateRoundTrips(t *testing.T) {
+	AssertParseAndValidateRoundTrips(t, "testutil-fake", map[string]interface{}{"name": "my-object"})
+}
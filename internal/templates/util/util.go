@@ -0,0 +1,46 @@
+// Package util holds small helpers shared by every template's generated gen-params.go:
+// parsing the embedded parameter-description JSON, and decoding a template's raw
+// map[string]interface{} config into its typed Params struct.
+package util
+
+import (
+	"encoding/json"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/internal/check"
+)
+
+// MustParseParameterDesc unmarshals a check.ParameterDesc from its JSON representation,
+// as embedded into gen-params.go by the codegen. It panics on failure since a malformed
+// literal here means the generator itself is broken, not that the input is untrusted.
+func MustParseParameterDesc(jsonStr string) check.ParameterDesc {
+	var desc check.ParameterDesc
+	if err := json.Unmarshal([]byte(jsonStr), &desc); err != nil {
+		panic(errors.Wrap(err, "parsing generated parameter description"))
+	}
+	return desc
+}
+
+// DecodeMapStructure decodes m into out (a pointer to a template's Params struct) via
+// mapstructure. WeaklyTypedInput is enabled so that, e.g., a YAML-decoded int can populate a
+// float64 field. Squash is enabled so that every anonymous (embedded) struct field is squashed
+// into its parent's namespace, which is what lets a +inline-tagged embedded Params struct (see
+// internal/templates/codegen) have its fields populated directly from the parent's keys in a
+// .kube-linter.yaml config, with no squash tag required on the field itself. +group fields are
+// declared as named (non-anonymous) fields, so Squash has no effect on them.
+func DecodeMapStructure(m map[string]interface{}, out interface{}) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           out,
+		WeaklyTypedInput: true,
+		ErrorUnused:      true,
+		Squash:           true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "constructing params decoder")
+	}
+	if err := decoder.Decode(m); err != nil {
+		return errors.Wrap(err, "decoding params")
+	}
+	return nil
+}
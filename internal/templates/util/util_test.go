@@ -0,0 +1,57 @@
+package util
+
+import "testing"
+
+// TestDecodeMapStructureSquashesInlinedEmbeddedStruct verifies that a plain anonymous struct
+// field -- the shape constructParameterDescsFromEmbeddedMember's +inline tag relies on to
+// promote a shared struct's fields into its parent -- is populated from the parent's own map
+// keys with no mapstructure squash tag required, exactly as a +inline Params struct needs.
+func TestDecodeMapStructureSquashesInlinedEmbeddedStruct(t *testing.T) {
+	type Selector struct {
+		Kind string
+	}
+	type Params struct {
+		Selector
+		Name string
+	}
+
+	var p Params
+	if err := DecodeMapStructure(map[string]interface{}{
+		"kind": "Deployment",
+		"name": "my-check",
+	}, &p); err != nil {
+		t.Fatalf("DecodeMapStructure: %v", err)
+	}
+
+	if p.Kind != "Deployment" {
+		t.Errorf("p.Kind = %q, want %q", p.Kind, "Deployment")
+	}
+	if p.Name != "my-check" {
+		t.Errorf("p.Name = %q, want %q", p.Name, "my-check")
+	}
+}
+
+// TestDecodeMapStructureDoesNotSquashNamedStructField verifies that a named, non-anonymous
+// field of struct type is decoded from its own nested map, not squashed into the parent's
+// namespace.
+func TestDecodeMapStructureDoesNotSquashNamedStructField(t *testing.T) {
+	type Selector struct {
+		Kind string
+	}
+	type Params struct {
+		MySelector Selector
+		Name       string
+	}
+
+	var p Params
+	if err := DecodeMapStructure(map[string]interface{}{
+		"myselector": map[string]interface{}{"kind": "Deployment"},
+		"name":       "my-check",
+	}, &p); err != nil {
+		t.Fatalf("DecodeMapStructure: %v", err)
+	}
+
+	if p.MySelector.Kind != "Deployment" {
+		t.Errorf("p.MySelector.Kind = %q, want %q", p.MySelector.Kind, "Deployment")
+	}
+}
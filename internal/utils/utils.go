@@ -0,0 +1,9 @@
+// Package utils holds small, generically useful helpers that don't belong to any more
+// specific internal package.
+package utils
+
+// IgnoreError calls f and discards its returned error, for use in defer statements
+// (e.g. `defer utils.IgnoreError(f.Close)`) where the error genuinely isn't actionable.
+func IgnoreError(f func() error) {
+	_ = f()
+}